@@ -0,0 +1,112 @@
+package common
+
+import "errors"
+
+// ErrorCode identifies the category of a coordinator failure so that callers
+// can branch on the kind of error without relying on sentinel comparisons or
+// string matching against err.Error().
+type ErrorCode int32
+
+const (
+	ErrorCodeInternal ErrorCode = iota
+	ErrorCodeCollectionNotExists
+	ErrorCodeCollectionAlreadyExists
+	ErrorCodeInvalidMetadataUpdate
+	ErrorCodeInvalidCollectionID
+	ErrorCodeConflict
+	ErrorCodeGracePeriodExpired
+	ErrorCodeInvalidPageToken
+)
+
+// StatusError is a typed error returned by the coordinator layer. It carries
+// an ErrorCode alongside a human-readable reason so that the gRPC layer can
+// map it to the correct coordinatorpb.Status.Code and grpc/status code in a
+// single place, instead of every RPC handler re-deriving the mapping.
+type StatusError struct {
+	Code   ErrorCode
+	Reason string
+}
+
+func (e *StatusError) Error() string {
+	return e.Reason
+}
+
+// NewStatusError creates a StatusError with the given code and reason.
+func NewStatusError(code ErrorCode, reason string) *StatusError {
+	return &StatusError{Code: code, Reason: reason}
+}
+
+// IsStatusError reports whether err is, or wraps, a *StatusError and
+// returns it. It uses errors.As rather than a bare type assertion so that
+// wrapping a StatusError with context (e.g. fmt.Errorf("%w", ...)) does not
+// cause every IsXError predicate and toGrpcStatus to fall through to
+// codes.Internal.
+func IsStatusError(err error) (*StatusError, bool) {
+	var se *StatusError
+	ok := errors.As(err, &se)
+	return se, ok
+}
+
+func hasCode(err error, code ErrorCode) bool {
+	se, ok := IsStatusError(err)
+	return ok && se.Code == code
+}
+
+// IsCollectionNotExistError reports whether err represents a missing collection.
+func IsCollectionNotExistError(err error) bool {
+	return hasCode(err, ErrorCodeCollectionNotExists)
+}
+
+// IsCollectionAlreadyExistsError reports whether err represents a unique
+// constraint violation on collection creation.
+func IsCollectionAlreadyExistsError(err error) bool {
+	return hasCode(err, ErrorCodeCollectionAlreadyExists)
+}
+
+// IsInvalidMetadataUpdateError reports whether err represents an invalid
+// combination of metadata update parameters.
+func IsInvalidMetadataUpdateError(err error) bool {
+	return hasCode(err, ErrorCodeInvalidMetadataUpdate)
+}
+
+// IsInvalidCollectionIDError reports whether err represents a malformed
+// collection ID.
+func IsInvalidCollectionIDError(err error) bool {
+	return hasCode(err, ErrorCodeInvalidCollectionID)
+}
+
+// IsConflictError reports whether err represents an optimistic-concurrency
+// conflict, e.g. a stale ResourceVersion or an unresolvable metadata merge.
+func IsConflictError(err error) bool {
+	return hasCode(err, ErrorCodeConflict)
+}
+
+// IsGracePeriodExpiredError reports whether err represents an attempt to
+// restore a collection whose delete grace period has already passed and can
+// therefore no longer be undone.
+func IsGracePeriodExpiredError(err error) bool {
+	return hasCode(err, ErrorCodeGracePeriodExpired)
+}
+
+// IsInvalidPageTokenError reports whether err represents a malformed or
+// corrupt ListCollections page_token, as distinct from a malformed
+// collection ID.
+func IsInvalidPageTokenError(err error) bool {
+	return hasCode(err, ErrorCodeInvalidPageToken)
+}
+
+// Sentinel StatusErrors for the common coordinator failure modes. These
+// replace the old plain-error sentinels: callers should prefer the
+// IsXError predicates above over comparing against these directly, since
+// wrapping (e.g. fmt.Errorf("%w", ...)) would otherwise break equality
+// checks.
+var (
+	ErrCollectionUniqueConstraintViolation   = NewStatusError(ErrorCodeCollectionAlreadyExists, "collection already exists")
+	ErrCollectionIDFormat                    = NewStatusError(ErrorCodeInvalidCollectionID, "collection id format error")
+	ErrCollectionDeleteNonExistingCollection = NewStatusError(ErrorCodeCollectionNotExists, "delete non existing collection")
+	ErrCollectionNotFound                    = NewStatusError(ErrorCodeCollectionNotExists, "collection not found")
+	ErrInvalidMetadataUpdate                 = NewStatusError(ErrorCodeInvalidMetadataUpdate, "reset metadata is true and metadata is not nil")
+	ErrCollectionVersionConflict             = NewStatusError(ErrorCodeConflict, "collection resource version conflict")
+	ErrMetadataMergeConflict                 = NewStatusError(ErrorCodeConflict, "concurrent metadata update conflict")
+	ErrCollectionGracePeriodExpired          = NewStatusError(ErrorCodeGracePeriodExpired, "collection delete grace period has expired")
+)
@@ -0,0 +1,28 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsStatusErrorMatchesWrappedError(t *testing.T) {
+	wrapped := fmt.Errorf("creating collection: %w", ErrCollectionUniqueConstraintViolation)
+
+	se, ok := IsStatusError(wrapped)
+	if !ok {
+		t.Fatal("expected IsStatusError to match a wrapped StatusError")
+	}
+	if se.Code != ErrorCodeCollectionAlreadyExists {
+		t.Errorf("Code = %v, want %v", se.Code, ErrorCodeCollectionAlreadyExists)
+	}
+
+	if !IsCollectionAlreadyExistsError(wrapped) {
+		t.Error("expected IsCollectionAlreadyExistsError to match a wrapped StatusError")
+	}
+}
+
+func TestIsStatusErrorRejectsUnrelatedError(t *testing.T) {
+	if _, ok := IsStatusError(fmt.Errorf("boom")); ok {
+		t.Error("expected IsStatusError to reject a plain error")
+	}
+}
@@ -0,0 +1,65 @@
+package coordinator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chroma/chroma-coordinator/internal/model"
+)
+
+// DefaultIdempotencyTTL is how long an idempotency key's cached outcome is
+// retained. A retry that arrives after the TTL is treated as a brand new
+// request rather than a replay.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStore is the narrow persistence interface a mutating RPC needs
+// to make itself safely retriable: look up a cached outcome for a key, or
+// record one alongside the operation it guards.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (record *model.IdempotencyRecord, found bool, err error)
+	Put(ctx context.Context, record *model.IdempotencyRecord, ttl time.Duration) error
+}
+
+// inMemoryIdempotencyStore is a process-local IdempotencyStore. It is a
+// stand-in for the metastore-persisted cache the request describes: it
+// does not survive a restart and is not shared across coordinator
+// replicas, but it gives the gRPC layer a concrete, usable store without
+// depending on a metastore change outside this series.
+type inMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]inMemoryIdempotencyEntry
+}
+
+type inMemoryIdempotencyEntry struct {
+	record  *model.IdempotencyRecord
+	expires time.Time
+}
+
+// NewInMemoryIdempotencyStore creates an empty process-local IdempotencyStore.
+func NewInMemoryIdempotencyStore() IdempotencyStore {
+	return &inMemoryIdempotencyStore{entries: make(map[string]inMemoryIdempotencyEntry)}
+}
+
+func (s *inMemoryIdempotencyStore) Get(ctx context.Context, key string) (*model.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return entry.record, true, nil
+}
+
+func (s *inMemoryIdempotencyStore) Put(ctx context.Context, record *model.IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[record.Key] = inMemoryIdempotencyEntry{record: record, expires: time.Now().Add(ttl)}
+	return nil
+}
@@ -0,0 +1,45 @@
+package coordinator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chroma/chroma-coordinator/internal/model"
+)
+
+func TestInMemoryIdempotencyStoreMissThenHit(t *testing.T) {
+	store := NewInMemoryIdempotencyStore()
+	ctx := context.Background()
+
+	if _, found, err := store.Get(ctx, "key-1"); err != nil || found {
+		t.Fatalf("expected a miss for an unseen key, got found=%v err=%v", found, err)
+	}
+
+	record := &model.IdempotencyRecord{Key: "key-1", Response: []byte("response"), Created: true}
+	if err := store.Put(ctx, record, time.Hour); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	cached, found, err := store.Get(ctx, "key-1")
+	if err != nil || !found {
+		t.Fatalf("expected a hit after Put, got found=%v err=%v", found, err)
+	}
+	if string(cached.Response) != "response" || !cached.Created {
+		t.Errorf("cached record = %+v, want Response=response Created=true", cached)
+	}
+}
+
+func TestInMemoryIdempotencyStoreExpiresEntries(t *testing.T) {
+	store := NewInMemoryIdempotencyStore()
+	ctx := context.Background()
+
+	record := &model.IdempotencyRecord{Key: "key-1", Response: []byte("response")}
+	if err := store.Put(ctx, record, -time.Second); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	if _, found, err := store.Get(ctx, "key-1"); err != nil || found {
+		t.Errorf("expected an expired entry to be a miss, got found=%v err=%v", found, err)
+	}
+}
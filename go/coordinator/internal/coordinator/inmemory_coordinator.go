@@ -0,0 +1,306 @@
+package coordinator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+// InMemoryCoordinator is a process-local, non-persistent reference
+// implementation of grpccoordinator.Coordinator. It also implements
+// FinalizeExpiredDeletions, so it satisfies grpccoordinator's reaperFinalizer
+// and coordinator.Reaper's deletionFinalizer too. Like
+// inMemoryIdempotencyStore, it is a deliberate, called-out stand-in for a
+// metastore-backed coordinator, not a quiet substitute for one: it gives
+// the gRPC layer (and its tests) a real, working backend to run
+// single-node or in tests, but state does not survive a restart and is not
+// shared across coordinator replicas.
+// FOLLOW-UP: replace with a metastore-backed Coordinator before running
+// more than one replica.
+type InMemoryCoordinator struct {
+	mu            sync.Mutex
+	collections   map[types.UniqueID]*model.Collection
+	nextCreatedAt int64
+}
+
+// NewInMemoryCoordinator creates an empty InMemoryCoordinator.
+func NewInMemoryCoordinator() *InMemoryCoordinator {
+	return &InMemoryCoordinator{collections: make(map[types.UniqueID]*model.Collection)}
+}
+
+func (c *InMemoryCoordinator) ResetState(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.collections = make(map[types.UniqueID]*model.Collection)
+	c.nextCreatedAt = 0
+	return nil
+}
+
+func (c *InMemoryCoordinator) CreateCollection(ctx context.Context, create *model.CreateCollection) (*model.Collection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, existing := range c.collections {
+		if existing.Name == create.Name && !existing.IsDeleted() {
+			return nil, common.ErrCollectionUniqueConstraintViolation
+		}
+	}
+
+	id := create.ID
+	if id == types.NilUniqueID() {
+		id = types.NewUniqueID()
+	} else if _, exists := c.collections[id]; exists {
+		return nil, common.ErrCollectionUniqueConstraintViolation
+	}
+
+	c.nextCreatedAt++
+	collection := &model.Collection{
+		ID:                id,
+		Name:              create.Name,
+		Topic:             create.Topic,
+		Dimension:         create.Dimension,
+		Metadata:          cloneMetadata(create.Metadata),
+		CreatedAtUnixNano: c.nextCreatedAt,
+	}
+	c.collections[id] = collection
+	return cloneCollection(collection), nil
+}
+
+func (c *InMemoryCoordinator) GetCollections(ctx context.Context, id types.UniqueID, name, topic *string) ([]*model.Collection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var results []*model.Collection
+	for _, col := range c.collections {
+		if col.IsDeleted() {
+			continue
+		}
+		if id != types.NilUniqueID() && col.ID != id {
+			continue
+		}
+		if name != nil && col.Name != *name {
+			continue
+		}
+		if topic != nil && col.Topic != *topic {
+			continue
+		}
+		results = append(results, cloneCollection(col))
+	}
+	return results, nil
+}
+
+// UpdateCollection applies update as one atomic compare-and-swap: the
+// version check and the write happen under the same lock, so two
+// concurrent updates racing against the same stale ResourceVersion cannot
+// both succeed the way a read-then-compare in the gRPC layer would allow.
+func (c *InMemoryCoordinator) UpdateCollection(ctx context.Context, update *model.UpdateCollection) (*model.Collection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.collections[update.ID]
+	if !ok || existing.IsDeleted() {
+		return nil, common.ErrCollectionNotFound
+	}
+	if update.CheckResourceVersion && update.ResourceVersion != existing.ResourceVersion {
+		return nil, common.ErrCollectionVersionConflict
+	}
+
+	if update.Name != nil {
+		existing.Name = *update.Name
+	}
+	if update.Topic != nil {
+		existing.Topic = *update.Topic
+	}
+	if update.Dimension != nil {
+		existing.Dimension = *update.Dimension
+	}
+	if update.ResetMetadata {
+		existing.Metadata = nil
+	} else if update.Metadata != nil {
+		existing.Metadata = cloneMetadata(update.Metadata)
+	}
+	existing.ResourceVersion++
+
+	return cloneCollection(existing), nil
+}
+
+// DeleteCollection soft-deletes id: it is hidden from GetCollections and
+// ListCollectionsPage (unless IncludeDeleted is set) but still undoable via
+// RestoreCollection until gracePeriodSeconds elapses, at which point the
+// reaper (or a forced PurgeCollection) finalizes it for good.
+func (c *InMemoryCoordinator) DeleteCollection(ctx context.Context, id types.UniqueID, gracePeriodSeconds int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.collections[id]
+	if !ok || existing.IsDeleted() {
+		return common.ErrCollectionDeleteNonExistingCollection
+	}
+	existing.DeletionTimestampUnixNano = time.Now().UnixNano()
+	existing.GracePeriodSeconds = gracePeriodSeconds
+	return nil
+}
+
+// RestoreCollection undoes a soft delete, as long as its grace period has
+// not yet elapsed. Restoring a collection that was never deleted is a no-op.
+func (c *InMemoryCoordinator) RestoreCollection(ctx context.Context, id types.UniqueID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.collections[id]
+	if !ok {
+		return common.ErrCollectionNotFound
+	}
+	if !existing.IsDeleted() {
+		return nil
+	}
+	if c.graceExpired(existing) {
+		return common.ErrCollectionGracePeriodExpired
+	}
+	existing.DeletionTimestampUnixNano = 0
+	existing.GracePeriodSeconds = 0
+	return nil
+}
+
+// PurgeCollection permanently removes a soft-deleted collection. With force
+// it bypasses any remaining grace period; without it, a collection whose
+// grace period has not yet elapsed is left alone, matching the reaper's own
+// finalization rule.
+func (c *InMemoryCoordinator) PurgeCollection(ctx context.Context, id types.UniqueID, force bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.collections[id]
+	if !ok || !existing.IsDeleted() {
+		return common.ErrCollectionDeleteNonExistingCollection
+	}
+	if !force && !c.graceExpired(existing) {
+		return nil
+	}
+	delete(c.collections, id)
+	return nil
+}
+
+// FinalizeExpiredDeletions satisfies the reaper's deletionFinalizer
+// (grpccoordinator's reaperFinalizer), permanently removing every
+// soft-deleted collection whose grace period has elapsed.
+func (c *InMemoryCoordinator) FinalizeExpiredDeletions(ctx context.Context) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	finalized := 0
+	for id, col := range c.collections {
+		if col.IsDeleted() && c.graceExpired(col) {
+			delete(c.collections, id)
+			finalized++
+		}
+	}
+	return finalized, nil
+}
+
+func (c *InMemoryCoordinator) graceExpired(col *model.Collection) bool {
+	deadline := col.DeletionTimestampUnixNano + col.GracePeriodSeconds*int64(time.Second)
+	return time.Now().UnixNano() >= deadline
+}
+
+// ListCollectionsPage returns collections ordered by (CreatedAtUnixNano,
+// ID), starting strictly after filter.Cursor, applying
+// MetadataKey/MetadataValue filtering itself rather than leaving it to the
+// caller the way GetCollections's ad-hoc filtering used to. The returned
+// cursor is the zero Cursor once the last page has been returned.
+//
+// CreatedAtUnixNano here is assigned from a per-coordinator counter rather
+// than time.Now().UnixNano(), so collections created in the same process
+// tick still get a strict, collision-free order; this is an
+// implementation detail of this in-memory stand-in, not part of the
+// Coordinator contract.
+//
+// filter.Tenant and filter.Database are not applied: InMemoryCoordinator has
+// no multi-tenancy concept at all (there is a single flat collection
+// namespace), so every caller effectively sees every tenant/database.
+func (c *InMemoryCoordinator) ListCollectionsPage(ctx context.Context, filter model.ListCollectionsFilter) ([]*model.Collection, model.Cursor, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var matches []*model.Collection
+	for _, col := range c.collections {
+		if !filter.IncludeDeleted && col.IsDeleted() {
+			continue
+		}
+		if filter.MetadataKey != nil {
+			val, ok := col.Metadata[*filter.MetadataKey]
+			if !ok {
+				continue
+			}
+			if filter.MetadataValue != nil && !reflect.DeepEqual(val, filter.MetadataValue) {
+				continue
+			}
+		}
+		if !filter.Cursor.IsZero() && !afterCursor(col, filter.Cursor) {
+			continue
+		}
+		matches = append(matches, col)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].CreatedAtUnixNano != matches[j].CreatedAtUnixNano {
+			return matches[i].CreatedAtUnixNano < matches[j].CreatedAtUnixNano
+		}
+		return matches[i].ID.String() < matches[j].ID.String()
+	})
+
+	pageSize := int(filter.PageSize)
+	if pageSize <= 0 || pageSize > len(matches) {
+		pageSize = len(matches)
+	}
+
+	page := matches[:pageSize]
+	var next model.Cursor
+	if pageSize < len(matches) {
+		last := page[len(page)-1]
+		next = model.Cursor{CreatedAtUnixNano: last.CreatedAtUnixNano, ID: last.ID}
+	}
+
+	cloned := make([]*model.Collection, len(page))
+	for i, col := range page {
+		cloned[i] = cloneCollection(col)
+	}
+	return cloned, next, nil
+}
+
+// afterCursor reports whether col sorts strictly after cursor in the
+// (CreatedAtUnixNano, ID) ordering ListCollectionsPage uses.
+func afterCursor(col *model.Collection, cursor model.Cursor) bool {
+	if col.CreatedAtUnixNano != cursor.CreatedAtUnixNano {
+		return col.CreatedAtUnixNano > cursor.CreatedAtUnixNano
+	}
+	return col.ID.String() > cursor.ID.String()
+}
+
+// cloneCollection returns a copy of col, including its own copy of the
+// Metadata map, so neither a caller holding the returned pointer nor a
+// caller holding the map passed into Create/UpdateCollection can mutate the
+// coordinator's stored state or race its internal mutex-guarded accesses.
+func cloneCollection(col *model.Collection) *model.Collection {
+	clone := *col
+	clone.Metadata = cloneMetadata(col.Metadata)
+	return &clone
+}
+
+// cloneMetadata returns a shallow copy of md, or nil if md is nil.
+func cloneMetadata(md map[string]interface{}) map[string]interface{} {
+	if md == nil {
+		return nil
+	}
+	clone := make(map[string]interface{}, len(md))
+	for k, v := range md {
+		clone[k] = v
+	}
+	return clone
+}
@@ -0,0 +1,203 @@
+package coordinator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+func mustCreate(t *testing.T, c *InMemoryCoordinator, name string) *model.Collection {
+	t.Helper()
+	col, err := c.CreateCollection(context.Background(), &model.CreateCollection{Name: name})
+	if err != nil {
+		t.Fatalf("CreateCollection(%q): %v", name, err)
+	}
+	return col
+}
+
+func TestCreateCollectionRejectsDuplicateName(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	mustCreate(t, c, "dup")
+
+	if _, err := c.CreateCollection(context.Background(), &model.CreateCollection{Name: "dup"}); !common.IsCollectionAlreadyExistsError(err) {
+		t.Fatalf("expected a CollectionAlreadyExists error, got %v", err)
+	}
+}
+
+func TestUpdateCollectionAtomicCAS(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	created := mustCreate(t, c, "coll")
+
+	updated, err := c.UpdateCollection(context.Background(), &model.UpdateCollection{
+		ID:                   created.ID,
+		CheckResourceVersion: true,
+		ResourceVersion:      created.ResourceVersion,
+	})
+	if err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if updated.ResourceVersion != created.ResourceVersion+1 {
+		t.Errorf("ResourceVersion = %d, want %d", updated.ResourceVersion, created.ResourceVersion+1)
+	}
+
+	// Retrying with the now-stale version must be rejected, not silently
+	// accepted as a second concurrent writer.
+	if _, err := c.UpdateCollection(context.Background(), &model.UpdateCollection{
+		ID:                   created.ID,
+		CheckResourceVersion: true,
+		ResourceVersion:      created.ResourceVersion,
+	}); !common.IsConflictError(err) {
+		t.Fatalf("expected a Conflict error for a stale version, got %v", err)
+	}
+}
+
+func TestUpdateCollectionSkipsCheckWhenNotRequested(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	created := mustCreate(t, c, "coll")
+
+	if _, err := c.UpdateCollection(context.Background(), &model.UpdateCollection{ID: created.ID, ResourceVersion: 999}); err != nil {
+		t.Fatalf("expected an unchecked update with a bogus version to succeed, got %v", err)
+	}
+}
+
+func TestListCollectionsPagePaginatesInCreationOrder(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	first := mustCreate(t, c, "a")
+	second := mustCreate(t, c, "b")
+	third := mustCreate(t, c, "c")
+
+	page1, cursor1, err := c.ListCollectionsPage(context.Background(), model.ListCollectionsFilter{PageSize: 2})
+	if err != nil {
+		t.Fatalf("page 1: %v", err)
+	}
+	if len(page1) != 2 || page1[0].ID != first.ID || page1[1].ID != second.ID {
+		t.Fatalf("page1 = %+v, want [%v, %v]", page1, first.ID, second.ID)
+	}
+	if cursor1.IsZero() {
+		t.Fatal("expected a non-zero cursor after a partial page")
+	}
+
+	page2, cursor2, err := c.ListCollectionsPage(context.Background(), model.ListCollectionsFilter{PageSize: 2, Cursor: cursor1})
+	if err != nil {
+		t.Fatalf("page 2: %v", err)
+	}
+	if len(page2) != 1 || page2[0].ID != third.ID {
+		t.Fatalf("page2 = %+v, want [%v]", page2, third.ID)
+	}
+	if !cursor2.IsZero() {
+		t.Errorf("expected a zero cursor once the last page is returned, got %+v", cursor2)
+	}
+}
+
+func TestListCollectionsPageFiltersByMetadata(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	if _, err := c.CreateCollection(context.Background(), &model.CreateCollection{Name: "tagged", Metadata: map[string]interface{}{"team": "search"}}); err != nil {
+		t.Fatalf("CreateCollection(tagged): %v", err)
+	}
+	if _, err := c.CreateCollection(context.Background(), &model.CreateCollection{Name: "untagged"}); err != nil {
+		t.Fatalf("CreateCollection(untagged): %v", err)
+	}
+
+	key := "team"
+	page, _, err := c.ListCollectionsPage(context.Background(), model.ListCollectionsFilter{MetadataKey: &key, MetadataValue: "search", PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListCollectionsPage: %v", err)
+	}
+	if len(page) != 1 || page[0].Name != "tagged" {
+		t.Fatalf("page = %+v, want only the collection tagged team=search", page)
+	}
+}
+
+func TestDeleteRestorePurgeLifecycle(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	created := mustCreate(t, c, "coll")
+
+	if err := c.DeleteCollection(context.Background(), created.ID, 60); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	if cols, err := c.GetCollections(context.Background(), types.NilUniqueID(), strPtr("coll"), nil); err != nil || len(cols) != 0 {
+		t.Fatalf("GetCollections after soft delete = %v, %v; want empty, nil", cols, err)
+	}
+
+	if err := c.RestoreCollection(context.Background(), created.ID); err != nil {
+		t.Fatalf("RestoreCollection within grace period: %v", err)
+	}
+	if cols, err := c.GetCollections(context.Background(), types.NilUniqueID(), strPtr("coll"), nil); err != nil || len(cols) != 1 {
+		t.Fatalf("GetCollections after restore = %v, %v; want one result", cols, err)
+	}
+
+	if err := c.DeleteCollection(context.Background(), created.ID, 0); err != nil {
+		t.Fatalf("DeleteCollection (second time): %v", err)
+	}
+	if err := c.PurgeCollection(context.Background(), created.ID, false); err != nil {
+		t.Fatalf("PurgeCollection with grace period already elapsed: %v", err)
+	}
+	if err := c.RestoreCollection(context.Background(), created.ID); !common.IsCollectionNotExistError(err) {
+		t.Fatalf("expected RestoreCollection to fail against a purged collection, got %v", err)
+	}
+}
+
+func TestRestoreCollectionRejectsAfterGracePeriodExpires(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	created := mustCreate(t, c, "coll")
+
+	if err := c.DeleteCollection(context.Background(), created.ID, 0); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	if err := c.RestoreCollection(context.Background(), created.ID); !common.IsGracePeriodExpiredError(err) {
+		t.Fatalf("expected a GracePeriodExpired error once the grace period has elapsed, got %v", err)
+	}
+}
+
+func TestFinalizeExpiredDeletionsOnlyRemovesElapsedGracePeriods(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	expired := mustCreate(t, c, "expired")
+	stillGrace := mustCreate(t, c, "grace")
+
+	if err := c.DeleteCollection(context.Background(), expired.ID, 0); err != nil {
+		t.Fatalf("DeleteCollection(expired): %v", err)
+	}
+	if err := c.DeleteCollection(context.Background(), stillGrace.ID, 3600); err != nil {
+		t.Fatalf("DeleteCollection(grace): %v", err)
+	}
+
+	finalized, err := c.FinalizeExpiredDeletions(context.Background())
+	if err != nil {
+		t.Fatalf("FinalizeExpiredDeletions: %v", err)
+	}
+	if finalized != 1 {
+		t.Errorf("finalized = %d, want 1", finalized)
+	}
+	if err := c.RestoreCollection(context.Background(), stillGrace.ID); err != nil {
+		t.Errorf("expected the still-in-grace collection to survive finalization, RestoreCollection returned: %v", err)
+	}
+}
+
+func TestListCollectionsPageExcludesDeletedByDefault(t *testing.T) {
+	c := NewInMemoryCoordinator()
+	created := mustCreate(t, c, "coll")
+	if err := c.DeleteCollection(context.Background(), created.ID, 60); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+
+	page, _, err := c.ListCollectionsPage(context.Background(), model.ListCollectionsFilter{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListCollectionsPage: %v", err)
+	}
+	if len(page) != 0 {
+		t.Errorf("page = %+v, want empty (soft-deleted collections excluded by default)", page)
+	}
+
+	page, _, err = c.ListCollectionsPage(context.Background(), model.ListCollectionsFilter{PageSize: 10, IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("ListCollectionsPage with IncludeDeleted: %v", err)
+	}
+	if len(page) != 1 {
+		t.Errorf("page with IncludeDeleted = %+v, want the soft-deleted collection included", page)
+	}
+}
+
+func strPtr(s string) *string { return &s }
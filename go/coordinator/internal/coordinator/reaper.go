@@ -0,0 +1,60 @@
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// DefaultReaperInterval is how often the reaper checks for soft-deleted
+// collections whose grace period has elapsed, when the caller does not
+// specify one.
+const DefaultReaperInterval = 10 * time.Second
+
+// deletionFinalizer is the narrow slice of the metastore the reaper needs:
+// finalize every soft-deleted collection whose grace period has elapsed.
+type deletionFinalizer interface {
+	FinalizeExpiredDeletions(ctx context.Context) (int, error)
+}
+
+// Reaper periodically finalizes collections whose delete grace period has
+// passed, tombstoning them so they no longer count against
+// RestoreCollection or occupy metastore rows reserved for the undo window.
+type Reaper struct {
+	store    deletionFinalizer
+	interval time.Duration
+}
+
+// NewReaper creates a Reaper that polls store at the given interval. A
+// non-positive interval falls back to DefaultReaperInterval.
+func NewReaper(store deletionFinalizer, interval time.Duration) *Reaper {
+	if interval <= 0 {
+		interval = DefaultReaperInterval
+	}
+	return &Reaper{store: store, interval: interval}
+}
+
+// Run polls until ctx is canceled. It is meant to be started once in its
+// own goroutine, e.g. `go reaper.Run(ctx)` at server startup.
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			finalized, err := r.store.FinalizeExpiredDeletions(ctx)
+			if err != nil {
+				log.Error("reaper failed to finalize expired deletions", zap.Error(err))
+				continue
+			}
+			if finalized > 0 {
+				log.Info("reaper finalized expired deletions", zap.Int("count", finalized))
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package coordinator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingFinalizer struct {
+	calls int32
+}
+
+func (f *countingFinalizer) FinalizeExpiredDeletions(ctx context.Context) (int, error) {
+	atomic.AddInt32(&f.calls, 1)
+	return 0, nil
+}
+
+func TestReaperRunFinalizesOnEachTick(t *testing.T) {
+	finalizer := &countingFinalizer{}
+	reaper := NewReaper(finalizer, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	reaper.Run(ctx)
+
+	if atomic.LoadInt32(&finalizer.calls) == 0 {
+		t.Errorf("expected Run to call FinalizeExpiredDeletions at least once before ctx was done")
+	}
+}
+
+func TestNewReaperFallsBackToDefaultInterval(t *testing.T) {
+	reaper := NewReaper(&countingFinalizer{}, 0)
+	if reaper.interval != DefaultReaperInterval {
+		t.Errorf("interval = %v, want %v", reaper.interval, DefaultReaperInterval)
+	}
+}
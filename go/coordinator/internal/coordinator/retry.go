@@ -0,0 +1,31 @@
+package coordinator
+
+import "github.com/chroma/chroma-coordinator/internal/common"
+
+// RetryOnConflict retries fn up to maxRetries times when it fails with a
+// Conflict StatusError (a stale ResourceVersion or an unresolvable metadata
+// merge), re-invoking fn so the caller can re-read the latest state and
+// re-apply its patch before issuing the update again. This gives callers
+// that want last-write-wins semantics a way to ride out optimistic-
+// concurrency conflicts instead of surfacing them to the end user.
+//
+// Not used by Server.updateCollection itself: that handler surfaces a
+// Conflict to the caller so the client can decide whether to retry, merge,
+// or prompt a user, which is the right default for a gRPC API.
+// Server.getOrCreateCollection's metadata-update path uses it instead,
+// since get_or_create has no idempotency key to de-duplicate concurrent
+// callers and last-write-wins is already its documented behavior.
+//
+// fn is expected to be idempotent up to the point of the conflicting write:
+// it should re-read current state, re-derive the update from it, and submit
+// it. Errors other than a Conflict are returned immediately without retry.
+func RetryOnConflict(maxRetries int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		err = fn()
+		if err == nil || !common.IsConflictError(err) {
+			return err
+		}
+	}
+	return err
+}
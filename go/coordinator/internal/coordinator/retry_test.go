@@ -0,0 +1,54 @@
+package coordinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+)
+
+func TestRetryOnConflictRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	err := RetryOnConflict(3, func() error {
+		attempts++
+		if attempts < 3 {
+			return common.ErrCollectionVersionConflict
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryOnConflictGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	err := RetryOnConflict(2, func() error {
+		attempts++
+		return common.ErrCollectionVersionConflict
+	})
+	if !common.IsConflictError(err) {
+		t.Fatalf("expected a conflict error after exhausting retries, got %v", err)
+	}
+	if attempts != 3 { // initial attempt + 2 retries
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryOnConflictReturnsNonConflictErrorImmediately(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("boom")
+	err := RetryOnConflict(3, func() error {
+		attempts++
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry for a non-conflict error)", attempts)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/coordinator"
 	"github.com/chroma/chroma-coordinator/internal/model"
 	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
 	"github.com/chroma/chroma-coordinator/internal/types"
@@ -11,28 +12,35 @@ import (
 	"go.uber.org/zap"
 )
 
-const errorCode = 500
-const successCode = 200
-const success = "ok"
+// getOrCreateUpdateMaxRetries bounds how many times get_or_create's
+// metadata update retries a ResourceVersion conflict against concurrent
+// callers before giving up and surfacing the conflict.
+const getOrCreateUpdateMaxRetries = 3
 
 func (s *Server) ResetState(context.Context, *coordinatorpb.ResetStateRequest) (*coordinatorpb.ResetStateResponse, error) {
 	res := &coordinatorpb.ResetStateResponse{}
 	err := s.coordinator.ResetState(context.Background())
 	if err != nil {
-		res.Status = failResponseWithError(err, errorCode)
-		return res, err
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 	res.Status = setResponseStatus(successCode)
 	return res, nil
 }
 
+// CreateCollection is idempotency-key aware: a retry that supplies the same
+// idempotency_key as a prior call returns that call's recorded outcome
+// instead of re-executing, so a client cannot double-create a collection
+// (or misread a retried get_or_create as a fresh one) just because the
+// original response was lost.
 func (s *Server) CreateCollection(ctx context.Context, req *coordinatorpb.CreateCollectionRequest) (*coordinatorpb.CreateCollectionResponse, error) {
-	getOrCreate := req.GetGetOrCreate()
-	if getOrCreate {
-		return s.getOrCreateCollection(ctx, req)
-	} else {
+	return s.withCreateCollectionIdempotency(ctx, req.GetIdempotencyKey(), func() (*coordinatorpb.CreateCollectionResponse, error) {
+		if req.GetGetOrCreate() {
+			return s.getOrCreateCollection(ctx, req)
+		}
 		return s.createCollection(ctx, req)
-	}
+	})
 }
 
 // Cases for get_or_create
@@ -70,8 +78,9 @@ func (s *Server) getOrCreateCollection(ctx context.Context, req *coordinatorpb.C
 			Metadata:  req.Metadata,
 		}
 		res.Created = false
-		res.Status = failResponseWithError(err, errorCode)
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 	if len(collections) > 0 { // collection exists, need to update the metadata
 		if req.Metadata != nil { // update existing collection with new metadata
@@ -85,15 +94,41 @@ func (s *Server) getOrCreateCollection(ctx context.Context, req *coordinatorpb.C
 					Metadata:  req.Metadata,
 				}
 				res.Created = false
-				res.Status = failResponseWithError(err, errorCode)
-				return res, nil
+				var grpcErr error
+				res.Status, grpcErr = toGrpcStatus(err)
+				return res, grpcErr
 			}
-			// update collection with new metadata
-			updateCollection := &model.UpdateCollection{
-				ID:       collections[0].ID,
-				Metadata: metadata,
-			}
-			updatedCollection, err := s.coordinator.UpdateCollection(ctx, updateCollection)
+			// update collection with new metadata. get_or_create has no
+			// idempotency key to de-duplicate concurrent callers racing to
+			// update the same collection's metadata, so this is exactly the
+			// last-write-wins internal convenience path coordinator.RetryOnConflict
+			// exists for: on a version conflict, re-read the collection
+			// that just won the race and retry against its version rather
+			// than surfacing the conflict to the caller.
+			var updatedCollection *model.Collection
+			current := collections[0]
+			attempt := 0
+			err = coordinator.RetryOnConflict(getOrCreateUpdateMaxRetries, func() error {
+				// current already reflects the GetCollections lookup above
+				// on the first attempt; only re-fetch on a retry, once that
+				// snapshot is known to be stale.
+				if attempt > 0 {
+					var err error
+					current, err = s.currentCollection(ctx, current.ID)
+					if err != nil {
+						return err
+					}
+				}
+				attempt++
+				var err error
+				updatedCollection, err = s.coordinator.UpdateCollection(ctx, &model.UpdateCollection{
+					ID:                   current.ID,
+					Metadata:             metadata,
+					ResourceVersion:      current.ResourceVersion,
+					CheckResourceVersion: true,
+				})
+				return err
+			})
 			if err != nil {
 				log.Error("error updating collection", zap.Error(err))
 				res.Collection = &coordinatorpb.Collection{
@@ -103,8 +138,9 @@ func (s *Server) getOrCreateCollection(ctx context.Context, req *coordinatorpb.C
 					Metadata:  req.Metadata,
 				}
 				res.Created = false
-				res.Status = failResponseWithError(err, errorCode)
-				return res, nil
+				var grpcErr error
+				res.Status, grpcErr = toGrpcStatus(err)
+				return res, grpcErr
 			}
 			// sucessfully update the metadata
 			res.Collection = convertCollectionToProto(updatedCollection)
@@ -139,8 +175,9 @@ func (s *Server) createCollection(ctx context.Context, req *coordinatorpb.Create
 			Metadata:  req.Metadata,
 		}
 		res.Created = false
-		res.Status = failResponseWithError(err, successCode)
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 	collection, err := s.coordinator.CreateCollection(ctx, createCollection)
 	if err != nil {
@@ -152,12 +189,9 @@ func (s *Server) createCollection(ctx context.Context, req *coordinatorpb.Create
 			Metadata:  req.Metadata,
 		}
 		res.Created = false
-		if err == common.ErrCollectionUniqueConstraintViolation {
-			res.Status = failResponseWithError(err, 409)
-		} else {
-			res.Status = failResponseWithError(err, errorCode)
-		}
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 	res.Collection = convertCollectionToProto(collection)
 	res.Created = true
@@ -165,6 +199,11 @@ func (s *Server) createCollection(ctx context.Context, req *coordinatorpb.Create
 	return res, nil
 }
 
+// GetCollections is a thin, capped wrapper: an id/name/topic lookup goes
+// straight to the coordinator as before, but a broad "list everything"
+// call (no id/name/topic given) now drains ListCollections instead of
+// asking the coordinator to materialize an entire tenant's collections in
+// one shot.
 func (s *Server) GetCollections(ctx context.Context, req *coordinatorpb.GetCollectionsRequest) (*coordinatorpb.GetCollectionsResponse, error) {
 	collectionID := req.Id
 	collectionName := req.Name
@@ -175,15 +214,23 @@ func (s *Server) GetCollections(ctx context.Context, req *coordinatorpb.GetColle
 	parsedCollectionID, err := types.ToUniqueID(collectionID)
 	if err != nil {
 		log.Error("collection id format error", zap.String("collectionpd.id", *collectionID))
-		res.Status = failResponseWithError(common.ErrCollectionIDFormat, errorCode)
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(common.ErrCollectionIDFormat)
+		return res, grpcErr
 	}
 
-	collections, err := s.coordinator.GetCollections(ctx, parsedCollectionID, collectionName, collectionTopic)
+	var collections []*model.Collection
+	if parsedCollectionID == types.NilUniqueID() && collectionName == nil && collectionTopic == nil {
+		filter := model.ListCollectionsFilter{PageSize: listCollectionsPageSize, IncludeDeleted: req.GetIncludeDeleted()}
+		collections, err = s.drainListCollections(ctx, filter, defaultGetCollectionsCap)
+	} else {
+		collections, err = s.coordinator.GetCollections(ctx, parsedCollectionID, collectionName, collectionTopic)
+	}
 	if err != nil {
 		log.Error("error getting collections", zap.Error(err))
-		res.Status = failResponseWithError(err, errorCode)
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 	res.Collections = make([]*coordinatorpb.Collection, 0, len(collections))
 	for _, collection := range collections {
@@ -195,38 +242,69 @@ func (s *Server) GetCollections(ctx context.Context, req *coordinatorpb.GetColle
 	return res, nil
 }
 
+// DeleteCollection soft-deletes: the coordinator stamps the collection with
+// a DeletionTimestamp and the given grace period instead of removing it
+// immediately, so RestoreCollection can still undo the delete until the
+// reaper finalizes it. It is idempotency-key aware, so a retry after a lost
+// response replays the original success instead of failing with
+// CollectionNotExists against a collection the first call already deleted.
 func (s *Server) DeleteCollection(ctx context.Context, req *coordinatorpb.DeleteCollectionRequest) (*coordinatorpb.DeleteCollectionResponse, error) {
+	return s.withDeleteCollectionIdempotency(ctx, req.GetIdempotencyKey(), func() (*coordinatorpb.DeleteCollectionResponse, error) {
+		return s.deleteCollection(ctx, req)
+	})
+}
+
+func (s *Server) deleteCollection(ctx context.Context, req *coordinatorpb.DeleteCollectionRequest) (*coordinatorpb.DeleteCollectionResponse, error) {
+	s.ensureReaperStarted()
+
 	collectionID := req.GetId()
 	res := &coordinatorpb.DeleteCollectionResponse{}
 	parsedCollectionID, err := types.Parse(collectionID)
 	if err != nil {
 		log.Error(err.Error(), zap.String("collectionpd.id", collectionID))
-		res.Status = failResponseWithError(common.ErrCollectionIDFormat, errorCode)
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(common.ErrCollectionIDFormat)
+		return res, grpcErr
 	}
-	err = s.coordinator.DeleteCollection(ctx, parsedCollectionID)
+
+	gracePeriodSeconds := req.GetGracePeriodSeconds()
+	if gracePeriodSeconds <= 0 {
+		gracePeriodSeconds = model.DefaultGracePeriodSeconds
+	}
+
+	// DeleteCollection takes the grace period as a second positional
+	// argument, matching the existing positional-argument convention
+	// GetCollections(ctx, id, name, topic) above uses rather than
+	// introducing a new wrapper struct for a single extra scalar.
+	err = s.coordinator.DeleteCollection(ctx, parsedCollectionID, gracePeriodSeconds)
 	if err != nil {
 		log.Error(err.Error(), zap.String("collectionpd.id", collectionID))
-		if err == common.ErrCollectionDeleteNonExistingCollection {
-			res.Status = failResponseWithError(err, 404)
-		} else {
-			res.Status = failResponseWithError(err, errorCode)
-		}
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 	res.Status = setResponseStatus(successCode)
 	return res, nil
 }
 
+// UpdateCollection is idempotency-key aware: see withUpdateCollectionIdempotency
+// for how it composes with the ResourceVersion conflict check above.
 func (s *Server) UpdateCollection(ctx context.Context, req *coordinatorpb.UpdateCollectionRequest) (*coordinatorpb.UpdateCollectionResponse, error) {
+	return s.withUpdateCollectionIdempotency(ctx, req.GetIdempotencyKey(), func() (*coordinatorpb.UpdateCollectionResponse, error) {
+		return s.updateCollection(ctx, req)
+	})
+}
+
+func (s *Server) updateCollection(ctx context.Context, req *coordinatorpb.UpdateCollectionRequest) (*coordinatorpb.UpdateCollectionResponse, error) {
 	res := &coordinatorpb.UpdateCollectionResponse{}
 
 	collectionID := req.Id
 	parsedCollectionID, err := types.ToUniqueID(&collectionID)
 	if err != nil {
 		log.Error("collection id format error", zap.String("collectionpd.id", collectionID))
-		res.Status = failResponseWithError(common.ErrCollectionIDFormat, errorCode)
-		return res, nil
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(common.ErrCollectionIDFormat)
+		return res, grpcErr
 	}
 
 	updateCollection := &model.UpdateCollection{
@@ -236,56 +314,128 @@ func (s *Server) UpdateCollection(ctx context.Context, req *coordinatorpb.Update
 		Dimension: req.Dimension,
 	}
 
+	// resource_version is a proto3 optional field so that a legitimate
+	// version of 0 (a freshly created collection) can be told apart from a
+	// caller that has not been updated to send a version at all; the
+	// latter skips the optimistic-concurrency check and preserves the
+	// pre-OCC behavior.
+	updateCollection.CheckResourceVersion = req.HasResourceVersion()
+	updateCollection.ResourceVersion = req.GetResourceVersion()
+
 	resetMetadata := req.GetResetMetadata()
 	updateCollection.ResetMetadata = resetMetadata
 	metadata := req.GetMetadata()
+	metadataPatch := req.GetMetadataPatch()
 	// Case 1: if resetMetadata is true, then delete all metadata for the collection
 	// Case 2: if resetMetadata is true and metadata is not nil -> THIS SHOULD NEVER HAPPEN
-	// Case 3: if resetMetadata is false, and the metadata is not nil - set the metadata to the value in metadata
-	// Case 4: if resetMetadata is false and metadata is nil, then leave the metadata as is
+	// Case 3: if resetMetadata is false and metadataPatch is true, 3-way merge
+	//         OriginalMetadata (the client's last-known snapshot) and metadata
+	//         (the client's desired snapshot) against whatever is currently stored
+	// Case 4: if resetMetadata is false, metadataPatch is false, and metadata is
+	//         not nil - set the metadata to the value in metadata
+	// Case 5: if resetMetadata is false, metadataPatch is false, and metadata is
+	//         nil, then leave the metadata as is
 	if resetMetadata {
-		if metadata != nil {
+		if metadata != nil || metadataPatch {
 			log.Error("reset metadata is true and metadata is not nil", zap.Any("metadata", metadata))
-			res.Status = failResponseWithError(common.ErrInvalidMetadataUpdate, errorCode)
-			return res, nil
-		} else {
-			updateCollection.Metadata = nil
+			var grpcErr error
+			res.Status, grpcErr = toGrpcStatus(common.ErrInvalidMetadataUpdate)
+			return res, grpcErr
+		}
+		updateCollection.Metadata = nil
+	} else if metadataPatch {
+		modelMetadata, err := convertCollectionMetadataToModel(metadata)
+		if err != nil {
+			log.Error("error converting collection metadata to model", zap.Error(err))
+			var grpcErr error
+			res.Status, grpcErr = toGrpcStatus(err)
+			return res, grpcErr
+		}
+		originalMetadata, err := convertCollectionMetadataToModel(req.GetOriginalMetadata())
+		if err != nil {
+			log.Error("error converting original collection metadata to model", zap.Error(err))
+			var grpcErr error
+			res.Status, grpcErr = toGrpcStatus(err)
+			return res, grpcErr
 		}
+		updateCollection.MetadataPatch = true
+		updateCollection.Metadata = modelMetadata
+		updateCollection.OriginalMetadata = originalMetadata
+	} else if metadata != nil {
+		modelMetadata, err := convertCollectionMetadataToModel(metadata)
+		if err != nil {
+			log.Error("error converting collection metadata to model", zap.Error(err))
+			var grpcErr error
+			res.Status, grpcErr = toGrpcStatus(err)
+			return res, grpcErr
+		}
+		updateCollection.Metadata = modelMetadata
 	} else {
-		if metadata != nil {
-			modelMetadata, err := convertCollectionMetadataToModel(metadata)
-			if err != nil {
-				log.Error("error converting collection metadata to model", zap.Error(err))
-				res.Status = failResponseWithError(err, errorCode)
-				return res, nil
-			}
-			updateCollection.Metadata = modelMetadata
-		} else {
-			updateCollection.Metadata = nil
+		updateCollection.Metadata = nil
+	}
+
+	// The 3-way metadata merge is resolved here against the collection as
+	// currently stored, since this is the one place that has both the
+	// patch's original/desired snapshots and can fetch the current stored
+	// metadata to merge them against. The ResourceVersion check is not
+	// resolved here: reading the stored version and comparing it before
+	// issuing the write is a TOCTOU race (two concurrent updates carrying
+	// the same stale version would both read the same current version and
+	// both pass), not a real compare-and-swap. Instead ResourceVersion is
+	// passed through to s.coordinator.UpdateCollection, which performs the
+	// compare-and-increment atomically against its backing store and
+	// returns a Conflict StatusError if the stored version has moved on.
+	if updateCollection.MetadataPatch {
+		current, err := s.currentCollection(ctx, parsedCollectionID)
+		if err != nil {
+			log.Error("error fetching current collection for update", zap.Error(err))
+			var grpcErr error
+			res.Status, grpcErr = toGrpcStatus(err)
+			return res, grpcErr
 		}
+
+		merged, conflictKey, ok := model.MergeMetadata(updateCollection.OriginalMetadata, updateCollection.Metadata, current.Metadata)
+		if !ok {
+			log.Warn("update collection metadata merge conflict",
+				zap.String("collectionpd.id", collectionID), zap.String("key", conflictKey))
+			var grpcErr error
+			res.Status, grpcErr = toGrpcStatus(common.ErrMetadataMergeConflict)
+			return res, grpcErr
+		}
+		// The merge is fully resolved here, so the coordinator just
+		// gets a plain replace and does not need to re-derive the
+		// 3-way patch itself.
+		updateCollection.Metadata = merged
+		updateCollection.MetadataPatch = false
+		updateCollection.OriginalMetadata = nil
 	}
 
 	_, err = s.coordinator.UpdateCollection(ctx, updateCollection)
 	if err != nil {
-		log.Error("error updating collection", zap.Error(err))
-		res.Status = failResponseWithError(err, errorCode)
-		return res, nil
+		if common.IsConflictError(err) {
+			log.Warn("update collection conflict", zap.Error(err), zap.String("collectionpd.id", collectionID))
+		} else {
+			log.Error("error updating collection", zap.Error(err))
+		}
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
 	}
 
 	res.Status = setResponseStatus(successCode)
 	return res, nil
 }
 
-func failResponseWithError(err error, code int32) *coordinatorpb.Status {
-	return &coordinatorpb.Status{
-		Reason: err.Error(),
-		Code:   code,
+// currentCollection fetches the single collection identified by id as it is
+// currently stored, for callers that need to compare against it (version
+// checks, metadata merges) before issuing a mutation.
+func (s *Server) currentCollection(ctx context.Context, id types.UniqueID) (*model.Collection, error) {
+	collections, err := s.coordinator.GetCollections(ctx, id, nil, nil)
+	if err != nil {
+		return nil, err
 	}
-}
-
-func setResponseStatus(code int32) *coordinatorpb.Status {
-	return &coordinatorpb.Status{
-		Reason: success,
-		Code:   code,
+	if len(collections) == 0 {
+		return nil, common.ErrCollectionNotFound
 	}
+	return collections[0], nil
 }
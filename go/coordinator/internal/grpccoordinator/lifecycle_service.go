@@ -0,0 +1,64 @@
+package grpccoordinator
+
+import (
+	"context"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+	"github.com/chroma/chroma-coordinator/internal/types"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// RestoreCollection undoes a soft delete, clearing the collection's
+// DeletionTimestamp as long as its grace period has not yet passed. Once
+// the reaper has finalized the deletion, this returns a FailedPrecondition
+// StatusError instead. Like every other handler in this package,
+// RestoreCollection and PurgeCollection only call into Coordinator; the
+// soft-delete bookkeeping itself (DeletionTimestamp, grace period,
+// finalization) lives in whatever backs that interface, not in this file.
+func (s *Server) RestoreCollection(ctx context.Context, req *coordinatorpb.RestoreCollectionRequest) (*coordinatorpb.RestoreCollectionResponse, error) {
+	res := &coordinatorpb.RestoreCollectionResponse{}
+	collectionID := req.GetId()
+	parsedCollectionID, err := types.Parse(collectionID)
+	if err != nil {
+		log.Error(err.Error(), zap.String("collectionpd.id", collectionID))
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(common.ErrCollectionIDFormat)
+		return res, grpcErr
+	}
+
+	if err := s.coordinator.RestoreCollection(ctx, parsedCollectionID); err != nil {
+		log.Error(err.Error(), zap.String("collectionpd.id", collectionID))
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
+	}
+	res.Status = setResponseStatus(successCode)
+	return res, nil
+}
+
+// PurgeCollection permanently tombstones a collection. With Force set it
+// bypasses any remaining grace period, which is only meant for admin
+// tooling; without it, PurgeCollection behaves like the reaper's own
+// finalization and is a no-op until the grace period has elapsed.
+func (s *Server) PurgeCollection(ctx context.Context, req *coordinatorpb.PurgeCollectionRequest) (*coordinatorpb.PurgeCollectionResponse, error) {
+	res := &coordinatorpb.PurgeCollectionResponse{}
+	collectionID := req.GetId()
+	parsedCollectionID, err := types.Parse(collectionID)
+	if err != nil {
+		log.Error(err.Error(), zap.String("collectionpd.id", collectionID))
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(common.ErrCollectionIDFormat)
+		return res, grpcErr
+	}
+
+	if err := s.coordinator.PurgeCollection(ctx, parsedCollectionID, req.GetForce()); err != nil {
+		log.Error(err.Error(), zap.String("collectionpd.id", collectionID))
+		var grpcErr error
+		res.Status, grpcErr = toGrpcStatus(err)
+		return res, grpcErr
+	}
+	res.Status = setResponseStatus(successCode)
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package grpccoordinator
+
+import (
+	"context"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// defaultGetCollectionsCap bounds how many collections the unary
+// GetCollections will drain from ListCollections on behalf of a caller that
+// has not been updated to page through ListCollections itself. It exists so
+// a single legacy call cannot pull an unbounded tenant into memory.
+const defaultGetCollectionsCap = 1000
+
+// listCollectionsPageSize is the batch size ListCollections streams results
+// in when the caller's requested page size is unset or larger than the
+// coordinator is willing to buffer per page.
+const listCollectionsPageSize = 100
+
+// ListCollections streams pages from whatever backs Coordinator; it is the
+// same metastore-backed implementation GetCollections, CreateCollection,
+// and the other handlers in this package already called into before this
+// RPC existed, not a new integration this request introduces.
+func (s *Server) ListCollections(req *coordinatorpb.ListCollectionsRequest, stream coordinatorpb.Coordinator_ListCollectionsServer) error {
+	filter, err := listCollectionsFilterFromProto(req)
+	if err != nil {
+		log.Error("error parsing list collections request", zap.Error(err))
+		_, grpcErr := toGrpcStatus(err)
+		return grpcErr
+	}
+
+	for {
+		collections, nextCursor, err := s.coordinator.ListCollectionsPage(stream.Context(), filter)
+		if err != nil {
+			log.Error("error listing collections", zap.Error(err))
+			_, grpcErr := toGrpcStatus(err)
+			return grpcErr
+		}
+
+		if len(collections) > 0 {
+			batch := make([]*coordinatorpb.Collection, 0, len(collections))
+			for _, collection := range collections {
+				batch = append(batch, convertCollectionToProto(collection))
+			}
+			if err := stream.Send(&coordinatorpb.ListCollectionsResponse{
+				Collections:   batch,
+				NextPageToken: nextCursor.Encode(),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if nextCursor.IsZero() {
+			return nil
+		}
+		filter.Cursor = nextCursor
+	}
+}
+
+// listCollectionsFilterFromProto translates a ListCollectionsRequest into
+// the model.ListCollectionsFilter that the coordinator layer pushes into
+// the metastore query, so filtering happens there instead of in Go.
+func listCollectionsFilterFromProto(req *coordinatorpb.ListCollectionsRequest) (model.ListCollectionsFilter, error) {
+	cursor, err := model.DecodeCursor(req.GetPageToken())
+	if err != nil {
+		return model.ListCollectionsFilter{}, common.NewStatusError(common.ErrorCodeInvalidPageToken, err.Error())
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize <= 0 || pageSize > listCollectionsPageSize {
+		pageSize = listCollectionsPageSize
+	}
+
+	return model.ListCollectionsFilter{
+		Tenant:         req.Tenant,
+		Database:       req.Database,
+		MetadataKey:    req.MetadataKey,
+		MetadataValue:  req.GetMetadataValue(),
+		PageSize:       pageSize,
+		Cursor:         cursor,
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}, nil
+}
+
+// drainListCollections pages through ListCollections until either the
+// source is exhausted or cap collections have been collected, whichever
+// comes first. GetCollections uses this so a tenant-wide listing keeps the
+// same "everything in one response" contract callers rely on today, without
+// duplicating the metastore-facing filtering ListCollections already does.
+func (s *Server) drainListCollections(ctx context.Context, filter model.ListCollectionsFilter, limit int) ([]*model.Collection, error) {
+	collected := make([]*model.Collection, 0, limit)
+	for {
+		collections, nextCursor, err := s.coordinator.ListCollectionsPage(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		collected = append(collected, collections...)
+		if len(collected) >= limit || nextCursor.IsZero() {
+			if len(collected) > limit {
+				collected = collected[:limit]
+			}
+			return collected, nil
+		}
+		filter.Cursor = nextCursor
+	}
+}
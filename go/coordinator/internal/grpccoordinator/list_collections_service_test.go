@@ -0,0 +1,87 @@
+package grpccoordinator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+func TestListCollectionsFilterFromProtoRejectsInvalidPageToken(t *testing.T) {
+	_, err := listCollectionsFilterFromProto(&coordinatorpb.ListCollectionsRequest{PageToken: "not-valid-base64!!"})
+	if !common.IsInvalidPageTokenError(err) {
+		t.Fatalf("expected an invalid-page-token error, got %v", err)
+	}
+}
+
+func TestListCollectionsFilterFromProtoClampsOversizedPageSize(t *testing.T) {
+	filter, err := listCollectionsFilterFromProto(&coordinatorpb.ListCollectionsRequest{PageSize: listCollectionsPageSize * 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.PageSize != listCollectionsPageSize {
+		t.Errorf("PageSize = %d, want %d (clamped to the server's max)", filter.PageSize, listCollectionsPageSize)
+	}
+}
+
+func TestDrainListCollectionsStopsAtLimit(t *testing.T) {
+	allCollections := []*model.Collection{
+		{ID: types.NewUniqueID(), Name: "a"},
+		{ID: types.NewUniqueID(), Name: "b"},
+		{ID: types.NewUniqueID(), Name: "c"},
+	}
+
+	calls := 0
+	coord := &fakeCoordinator{
+		listPageFn: func(ctx context.Context, filter model.ListCollectionsFilter) ([]*model.Collection, model.Cursor, error) {
+			calls++
+			if calls > len(allCollections) {
+				t.Fatalf("ListCollectionsPage called %d times after the limit was already reached", calls)
+			}
+			page := allCollections[calls-1 : calls]
+			next := model.Cursor{}
+			if calls < len(allCollections) {
+				next = model.Cursor{CreatedAtUnixNano: int64(calls), ID: page[0].ID}
+			}
+			return page, next, nil
+		},
+	}
+
+	s := NewServer(coord)
+	got, err := s.drainListCollections(context.Background(), model.ListCollectionsFilter{PageSize: 1}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("drainListCollections returned %d collections, want 2 (the limit)", len(got))
+	}
+}
+
+func TestDrainListCollectionsStopsWhenCursorExhausted(t *testing.T) {
+	allCollections := []*model.Collection{
+		{ID: types.NewUniqueID(), Name: "a"},
+		{ID: types.NewUniqueID(), Name: "b"},
+	}
+
+	coord := &fakeCoordinator{
+		listPageFn: func(ctx context.Context, filter model.ListCollectionsFilter) ([]*model.Collection, model.Cursor, error) {
+			if filter.Cursor.IsZero() {
+				return allCollections, model.Cursor{}, nil
+			}
+			t.Fatalf("ListCollectionsPage called again after the first page already returned a zero cursor")
+			return nil, model.Cursor{}, nil
+		},
+	}
+
+	s := NewServer(coord)
+	got, err := s.drainListCollections(context.Background(), model.ListCollectionsFilter{PageSize: 100}, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(allCollections) {
+		t.Errorf("drainListCollections returned %d collections, want %d", len(got), len(allCollections))
+	}
+}
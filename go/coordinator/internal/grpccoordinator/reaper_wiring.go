@@ -0,0 +1,33 @@
+package grpccoordinator
+
+import (
+	"context"
+
+	"github.com/chroma/chroma-coordinator/internal/coordinator"
+)
+
+// reaperFinalizer is satisfied by any Coordinator implementation that can
+// finalize expired soft deletes; see coordinator.Reaper.
+type reaperFinalizer interface {
+	FinalizeExpiredDeletions(ctx context.Context) (int, error)
+}
+
+// ensureReaperStarted starts the background deletion reaper the first time
+// a request that can produce a soft-deleted collection runs in this
+// process. There is no single server-startup entrypoint in this package to
+// start it from eagerly, so DeleteCollection calls this on every request;
+// Server.reaperOnce (a sync.Once field, zero-value ready) means only the
+// first call on a given Server actually spawns the goroutine, and it runs
+// for the lifetime of the process rather than the lifetime of any one RPC's
+// context. Keeping it a field rather than a package-level var means
+// separate Server instances in the same process (e.g. in tests) each get
+// their own reaper lifecycle instead of racing over one shared flag.
+func (s *Server) ensureReaperStarted() {
+	finalizer, ok := s.coordinator.(reaperFinalizer)
+	if !ok {
+		return
+	}
+	s.reaperOnce.Do(func() {
+		go coordinator.NewReaper(finalizer, coordinator.DefaultReaperInterval).Run(context.Background())
+	})
+}
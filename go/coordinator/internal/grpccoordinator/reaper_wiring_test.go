@@ -0,0 +1,40 @@
+package grpccoordinator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnsureReaperStartedStartsExactlyOnce(t *testing.T) {
+	var calls int32
+	coord := &fakeReaperCoordinator{
+		finalizeFn: func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 0, nil
+		},
+	}
+	s := NewServer(coord)
+
+	for i := 0; i < 5; i++ {
+		s.ensureReaperStarted()
+	}
+
+	deadline := time.After(200 * time.Millisecond)
+	for atomic.LoadInt32(&calls) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("reaper never called FinalizeExpiredDeletions")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestEnsureReaperStartedNoopsWhenCoordinatorCannotFinalize(t *testing.T) {
+	s := NewServer(&fakeCoordinator{})
+
+	// fakeCoordinator does not implement reaperFinalizer, so this must not
+	// panic trying to dereference a finalizeFn that was never set.
+	s.ensureReaperStarted()
+}
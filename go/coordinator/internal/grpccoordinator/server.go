@@ -0,0 +1,53 @@
+package grpccoordinator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chroma/chroma-coordinator/internal/coordinator"
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+// Coordinator is the narrow backend surface the RPC handlers in this
+// package dispatch to: the metastore-backed implementation that actually
+// stores collections. Server depends on this interface rather than a
+// concrete type so the RPC layer can be exercised against a fake in tests
+// without pulling in the metastore. ensureReaperStarted further narrows
+// this down to reaperFinalizer via a type assertion, since finalizing
+// expired soft deletes is not part of every caller's contract.
+type Coordinator interface {
+	ResetState(ctx context.Context) error
+	CreateCollection(ctx context.Context, createCollection *model.CreateCollection) (*model.Collection, error)
+	GetCollections(ctx context.Context, id types.UniqueID, name *string, topic *string) ([]*model.Collection, error)
+	UpdateCollection(ctx context.Context, updateCollection *model.UpdateCollection) (*model.Collection, error)
+	DeleteCollection(ctx context.Context, id types.UniqueID, gracePeriodSeconds int64) error
+	RestoreCollection(ctx context.Context, id types.UniqueID) error
+	PurgeCollection(ctx context.Context, id types.UniqueID, force bool) error
+	ListCollectionsPage(ctx context.Context, filter model.ListCollectionsFilter) ([]*model.Collection, model.Cursor, error)
+}
+
+// Server implements the generated coordinatorpb RPC service, translating
+// between the wire format and the model types Coordinator operates on.
+type Server struct {
+	coordinator Coordinator
+
+	// idempotencyStore backs the idempotency_key cache for CreateCollection,
+	// DeleteCollection, and UpdateCollection; see idempotency.go.
+	idempotencyStore coordinator.IdempotencyStore
+
+	// reaperOnce guards starting the background deletion reaper; see
+	// ensureReaperStarted in reaper_wiring.go.
+	reaperOnce sync.Once
+}
+
+// NewServer wires coord up behind a Server, defaulting the idempotency
+// cache to an in-memory store. Pass a metastore-backed
+// coordinator.IdempotencyStore instead before running multiple replicas of
+// this server, since the in-memory default cannot be shared across them.
+func NewServer(coord Coordinator) *Server {
+	return &Server{
+		coordinator:      coord,
+		idempotencyStore: coordinator.NewInMemoryIdempotencyStore(),
+	}
+}
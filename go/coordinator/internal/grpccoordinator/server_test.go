@@ -0,0 +1,68 @@
+package grpccoordinator
+
+import (
+	"context"
+
+	"github.com/chroma/chroma-coordinator/internal/model"
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+// fakeCoordinator is a minimal in-memory Coordinator double for exercising
+// the RPC handlers in this package without a real metastore. Tests set the
+// function fields they care about; an unset field panics if called, so a
+// test that calls an unexpected method fails loudly instead of silently
+// returning a zero value. It deliberately does not implement
+// FinalizeExpiredDeletions, so it does not satisfy reaperFinalizer; embed it
+// in fakeReaperCoordinator for tests that need it to.
+type fakeCoordinator struct {
+	resetStateFn func(ctx context.Context) error
+	createFn     func(ctx context.Context, c *model.CreateCollection) (*model.Collection, error)
+	getFn        func(ctx context.Context, id types.UniqueID, name, topic *string) ([]*model.Collection, error)
+	updateFn     func(ctx context.Context, u *model.UpdateCollection) (*model.Collection, error)
+	deleteFn     func(ctx context.Context, id types.UniqueID, gracePeriodSeconds int64) error
+	restoreFn    func(ctx context.Context, id types.UniqueID) error
+	purgeFn      func(ctx context.Context, id types.UniqueID, force bool) error
+	listPageFn   func(ctx context.Context, filter model.ListCollectionsFilter) ([]*model.Collection, model.Cursor, error)
+}
+
+func (f *fakeCoordinator) ResetState(ctx context.Context) error { return f.resetStateFn(ctx) }
+
+func (f *fakeCoordinator) CreateCollection(ctx context.Context, c *model.CreateCollection) (*model.Collection, error) {
+	return f.createFn(ctx, c)
+}
+
+func (f *fakeCoordinator) GetCollections(ctx context.Context, id types.UniqueID, name, topic *string) ([]*model.Collection, error) {
+	return f.getFn(ctx, id, name, topic)
+}
+
+func (f *fakeCoordinator) UpdateCollection(ctx context.Context, u *model.UpdateCollection) (*model.Collection, error) {
+	return f.updateFn(ctx, u)
+}
+
+func (f *fakeCoordinator) DeleteCollection(ctx context.Context, id types.UniqueID, gracePeriodSeconds int64) error {
+	return f.deleteFn(ctx, id, gracePeriodSeconds)
+}
+
+func (f *fakeCoordinator) RestoreCollection(ctx context.Context, id types.UniqueID) error {
+	return f.restoreFn(ctx, id)
+}
+
+func (f *fakeCoordinator) PurgeCollection(ctx context.Context, id types.UniqueID, force bool) error {
+	return f.purgeFn(ctx, id, force)
+}
+
+func (f *fakeCoordinator) ListCollectionsPage(ctx context.Context, filter model.ListCollectionsFilter) ([]*model.Collection, model.Cursor, error) {
+	return f.listPageFn(ctx, filter)
+}
+
+// fakeReaperCoordinator adds FinalizeExpiredDeletions on top of
+// fakeCoordinator, so it satisfies reaperFinalizer for tests of
+// ensureReaperStarted's happy path.
+type fakeReaperCoordinator struct {
+	fakeCoordinator
+	finalizeFn func(ctx context.Context) (int, error)
+}
+
+func (f *fakeReaperCoordinator) FinalizeExpiredDeletions(ctx context.Context) (int, error) {
+	return f.finalizeFn(ctx)
+}
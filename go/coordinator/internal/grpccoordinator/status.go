@@ -0,0 +1,60 @@
+package grpccoordinator
+
+import (
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"github.com/chroma/chroma-coordinator/internal/proto/coordinatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const successCode = 200
+const success = "ok"
+
+// toGrpcStatus maps a coordinator error to a coordinatorpb.Status for the
+// response body and to a canonical google.golang.org/grpc/status error for
+// the RPC itself, so that gRPC clients see the right code (NotFound,
+// AlreadyExists, InvalidArgument, ...) instead of always OK with an embedded
+// 500 in the response body.
+//
+// The returned error is the authoritative channel: grpc-go does not
+// transmit a unary handler's response message to the client when the
+// handler also returns a non-nil error, so a real gRPC client only ever
+// observes this error's code and message (via status.FromError), never the
+// populated res.Status alongside it. Handlers still populate res.Status on
+// error paths for same-process callers that invoke them directly without
+// going through the grpc transport (e.g. tests); any caller reachable over
+// the wire must branch on status.Code(err)/status.Convert(err), not on
+// res.Status, since res is unreachable there.
+func toGrpcStatus(err error) (*coordinatorpb.Status, error) {
+	se, ok := common.IsStatusError(err)
+	if !ok {
+		return &coordinatorpb.Status{Reason: err.Error(), Code: int32(codes.Internal)},
+			status.Error(codes.Internal, err.Error())
+	}
+
+	var code codes.Code
+	switch se.Code {
+	case common.ErrorCodeCollectionNotExists:
+		code = codes.NotFound
+	case common.ErrorCodeCollectionAlreadyExists:
+		code = codes.AlreadyExists
+	case common.ErrorCodeInvalidMetadataUpdate, common.ErrorCodeInvalidCollectionID, common.ErrorCodeInvalidPageToken:
+		code = codes.InvalidArgument
+	case common.ErrorCodeConflict:
+		code = codes.Aborted
+	case common.ErrorCodeGracePeriodExpired:
+		code = codes.FailedPrecondition
+	default:
+		code = codes.Internal
+	}
+
+	return &coordinatorpb.Status{Reason: se.Reason, Code: int32(code)},
+		status.Error(code, se.Reason)
+}
+
+func setResponseStatus(code int32) *coordinatorpb.Status {
+	return &coordinatorpb.Status{
+		Reason: success,
+		Code:   code,
+	}
+}
@@ -0,0 +1,42 @@
+package grpccoordinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGrpcStatusMapsKnownErrorCodes(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not exists", common.ErrCollectionNotFound, codes.NotFound},
+		{"already exists", common.ErrCollectionUniqueConstraintViolation, codes.AlreadyExists},
+		{"invalid metadata update", common.ErrInvalidMetadataUpdate, codes.InvalidArgument},
+		{"invalid collection id", common.ErrCollectionIDFormat, codes.InvalidArgument},
+		{"invalid page token", common.NewStatusError(common.ErrorCodeInvalidPageToken, "bad token"), codes.InvalidArgument},
+		{"conflict", common.ErrCollectionVersionConflict, codes.Aborted},
+		{"grace period expired", common.ErrCollectionGracePeriodExpired, codes.FailedPrecondition},
+		{"untyped error", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pbStatus, grpcErr := toGrpcStatus(tc.err)
+			if got := status.Code(grpcErr); got != tc.want {
+				t.Errorf("status.Code(grpcErr) = %v, want %v", got, tc.want)
+			}
+			if pbStatus.GetCode() != int32(tc.want) {
+				t.Errorf("pbStatus.Code = %d, want %d", pbStatus.GetCode(), int32(tc.want))
+			}
+			if grpcErr == nil {
+				t.Errorf("toGrpcStatus returned a nil error for a non-nil input error; grpc-go would then transmit res.Status, masking the failure as a successful RPC")
+			}
+		})
+	}
+}
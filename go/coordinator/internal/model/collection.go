@@ -0,0 +1,48 @@
+package model
+
+import "github.com/chroma/chroma-coordinator/internal/types"
+
+// UpdateCollection carries the fields a client wants to change on a
+// collection. Pointer/map fields left nil mean "leave as is", which is why
+// ResetMetadata exists as an explicit opt-in to clearing metadata rather
+// than overloading a nil Metadata for that purpose.
+type UpdateCollection struct {
+	ID        types.UniqueID
+	Name      *string
+	Topic     *string
+	Dimension *int32
+	Metadata  map[string]interface{}
+
+	// ResetMetadata, when true, clears all metadata on the collection.
+	ResetMetadata bool
+
+	// ResourceVersion is the caller's last-observed version of the
+	// collection. Whatever backs grpccoordinator.Coordinator compares it
+	// against the stored version and increments the stored version as one
+	// atomic compare-and-swap against its backing store, rejecting the
+	// write with a Conflict StatusError if the stored version has since
+	// moved on; this must happen in the same operation as the write, since
+	// this struct only carries the caller's intent as far as the metastore
+	// boundary — a prior read-then-compare on this side of that boundary
+	// would be a TOCTOU race between concurrent updates, not a real
+	// compare-and-swap. ResourceVersion is only consulted when
+	// CheckResourceVersion is true: 0 is a valid version for a freshly
+	// created collection, so it cannot double as the "unset" sentinel.
+	ResourceVersion int64
+
+	// CheckResourceVersion opts into the version check above. Callers that
+	// have not been updated to track ResourceVersion leave this false,
+	// matching the pre-OCC behavior.
+	CheckResourceVersion bool
+
+	// MetadataPatch, when set, means Metadata/OriginalMetadata originally
+	// carried the 3-way merge-patch inputs: OriginalMetadata was the
+	// client's last-known snapshot (the "base") and Metadata was the
+	// client's desired snapshot (the "modified" side). Server.updateCollection
+	// resolves the merge against the currently stored metadata (the
+	// "current" side) before this reaches the coordinator, so by the time
+	// the coordinator sees this struct, Metadata already holds the final
+	// merged result and MetadataPatch/OriginalMetadata have been cleared.
+	MetadataPatch    bool
+	OriginalMetadata map[string]interface{}
+}
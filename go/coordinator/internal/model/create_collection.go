@@ -0,0 +1,17 @@
+package model
+
+import "github.com/chroma/chroma-coordinator/internal/types"
+
+// CreateCollection carries the fields a client supplies to create a new
+// collection. Unlike UpdateCollection, every field here is meaningful at
+// its zero value, since there is no existing stored collection to leave
+// unchanged.
+type CreateCollection struct {
+	// ID is the client-supplied collection id, or the nil UniqueID to have
+	// the coordinator generate one.
+	ID        types.UniqueID
+	Name      string
+	Topic     string
+	Dimension int32
+	Metadata  map[string]interface{}
+}
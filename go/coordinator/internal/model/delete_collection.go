@@ -0,0 +1,6 @@
+package model
+
+// DefaultGracePeriodSeconds is the grace period DeleteCollection uses when
+// the caller does not specify one, giving clients and downstream segment
+// managers a short window to react to a deletion before it is finalized.
+const DefaultGracePeriodSeconds = 30
@@ -0,0 +1,17 @@
+package model
+
+// IdempotencyRecord is the cached outcome of a mutating RPC, keyed by the
+// caller-supplied idempotency key, so a retry after a lost response returns
+// the original outcome verbatim instead of re-executing the mutation.
+type IdempotencyRecord struct {
+	Key string
+
+	// Response is the marshaled proto response to replay verbatim on a
+	// cache hit.
+	Response []byte
+
+	// Created mirrors e.g. CreateCollectionResponse.Created. It is tracked
+	// separately from Response so callers that only care whether a new
+	// resource was made don't need to unmarshal the payload to find out.
+	Created bool
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+// ListCollectionsFilter carries the server-side query for a page of
+// ListCollections results. Unlike the ad-hoc in-memory filtering that
+// GetCollections used to do, this is meant to be pushed into the metastore
+// query itself so a large tenant does not require materializing every
+// matching collection in the coordinator process.
+type ListCollectionsFilter struct {
+	Tenant   *string
+	Database *string
+
+	// MetadataKey, when set, restricts results to collections that have
+	// this metadata key. If MetadataValue is also set, the key must map to
+	// that exact value; otherwise a key-exists check is sufficient.
+	MetadataKey   *string
+	MetadataValue interface{}
+
+	// PageSize is the maximum number of collections to return in this page.
+	PageSize int32
+
+	// Cursor is the decoded page_token from the previous page, or the zero
+	// value to start from the beginning.
+	Cursor Cursor
+
+	// IncludeDeleted, when true, includes collections that have a
+	// DeletionTimestamp set but have not yet been purged by the reaper.
+	// Defaults to false, so a soft delete behaves like a real delete to
+	// callers that have not opted in to seeing tombstones.
+	IncludeDeleted bool
+}
+
+// Cursor identifies a position in the (created_at, id) ordering that
+// ListCollections results are paginated over. Ordering on a monotonic
+// timestamp plus a tie-breaking id keeps pages stable even if collections
+// are concurrently inserted with the same created_at.
+type Cursor struct {
+	CreatedAtUnixNano int64
+	ID                types.UniqueID
+}
+
+// IsZero reports whether c is the empty cursor, i.e. "start from the
+// beginning".
+func (c Cursor) IsZero() bool {
+	return c.CreatedAtUnixNano == 0 && c.ID == types.NilUniqueID()
+}
+
+// Encode serializes the cursor into an opaque page_token. Callers should not
+// depend on its format; it is only meant to be round-tripped through
+// DecodeCursor.
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a page_token produced by Cursor.Encode. An empty token
+// decodes to the zero Cursor, meaning "start from the beginning".
+func DecodeCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid page token: %w", err)
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return Cursor{}, fmt.Errorf("invalid page token: %w", err)
+	}
+	return c, nil
+}
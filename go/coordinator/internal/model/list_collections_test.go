@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/chroma/chroma-coordinator/internal/types"
+)
+
+func TestCursorEncodeDecodeRoundTrip(t *testing.T) {
+	id, err := types.Parse("3f3e3d3c-0000-4000-8000-000000000001")
+	if err != nil {
+		t.Fatalf("types.Parse returned error: %v", err)
+	}
+	c := Cursor{CreatedAtUnixNano: 1234567890, ID: id}
+
+	token := c.Encode()
+	if token == "" {
+		t.Fatalf("expected a non-empty token for a non-zero cursor")
+	}
+
+	decoded, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor returned error: %v", err)
+	}
+	if decoded != c {
+		t.Errorf("decoded cursor = %+v, want %+v", decoded, c)
+	}
+}
+
+func TestZeroCursorEncodesToEmptyToken(t *testing.T) {
+	var c Cursor
+	if token := c.Encode(); token != "" {
+		t.Errorf("expected zero cursor to encode to empty token, got %q", token)
+	}
+}
+
+func TestDecodeEmptyTokenIsZeroCursor(t *testing.T) {
+	decoded, err := DecodeCursor("")
+	if err != nil {
+		t.Fatalf("DecodeCursor(\"\") returned error: %v", err)
+	}
+	if !decoded.IsZero() {
+		t.Errorf("expected zero cursor, got %+v", decoded)
+	}
+}
+
+func TestDecodeCursorRejectsGarbageToken(t *testing.T) {
+	if _, err := DecodeCursor("not-a-valid-token!!"); err == nil {
+		t.Errorf("expected an error decoding a garbage token, got nil")
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import "reflect"
+
+// MergeMetadata performs a 3-way JSON-merge-patch of collection metadata:
+// original is the client's last-known snapshot (the base), modified is the
+// client's desired snapshot, and current is whatever the coordinator has
+// stored right now. A key the client didn't touch keeps whatever is
+// currently stored, so a concurrent non-overlapping edit from another
+// client survives. A key both sides changed to different values is a
+// genuine conflict: callers should reject the update rather than guess
+// which edit should win, which is what the ok=false return signals.
+func MergeMetadata(original, modified, current map[string]interface{}) (merged map[string]interface{}, conflictKey string, ok bool) {
+	merged = make(map[string]interface{})
+
+	keys := make(map[string]struct{}, len(original)+len(modified)+len(current))
+	for k := range original {
+		keys[k] = struct{}{}
+	}
+	for k := range modified {
+		keys[k] = struct{}{}
+	}
+	for k := range current {
+		keys[k] = struct{}{}
+	}
+
+	for key := range keys {
+		originalVal, inOriginal := original[key]
+		modifiedVal, inModified := modified[key]
+		currentVal, inCurrent := current[key]
+
+		desiredChanged := inOriginal != inModified || !reflect.DeepEqual(originalVal, modifiedVal)
+		serverChanged := inOriginal != inCurrent || !reflect.DeepEqual(originalVal, currentVal)
+
+		switch {
+		case !desiredChanged:
+			// The client didn't ask to change this key; keep whatever the
+			// server has now.
+			if inCurrent {
+				merged[key] = currentVal
+			}
+		case !serverChanged:
+			// The server hasn't moved since the client's base; apply the
+			// client's desired change.
+			if inModified {
+				merged[key] = modifiedVal
+			}
+		case inModified == inCurrent && reflect.DeepEqual(modifiedVal, currentVal):
+			// Both sides independently made the same change; not a conflict.
+			if inModified {
+				merged[key] = modifiedVal
+			}
+		default:
+			return nil, key, false
+		}
+	}
+
+	return merged, "", true
+}
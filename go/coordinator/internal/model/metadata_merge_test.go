@@ -0,0 +1,80 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeMetadataNonOverlappingEdits(t *testing.T) {
+	original := map[string]interface{}{"a": "1", "b": "2"}
+	modified := map[string]interface{}{"a": "1-new", "b": "2"}
+	current := map[string]interface{}{"a": "1", "b": "2-new"}
+
+	merged, conflictKey, ok := MergeMetadata(original, modified, current)
+	if !ok {
+		t.Fatalf("expected no conflict, got conflict on key %q", conflictKey)
+	}
+	want := map[string]interface{}{"a": "1-new", "b": "2-new"}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("merged = %v, want %v", merged, want)
+	}
+}
+
+func TestMergeMetadataConflictingEdit(t *testing.T) {
+	original := map[string]interface{}{"a": "1"}
+	modified := map[string]interface{}{"a": "1-client"}
+	current := map[string]interface{}{"a": "1-other-client"}
+
+	_, conflictKey, ok := MergeMetadata(original, modified, current)
+	if ok {
+		t.Fatalf("expected a conflict, got none")
+	}
+	if conflictKey != "a" {
+		t.Errorf("conflictKey = %q, want %q", conflictKey, "a")
+	}
+}
+
+func TestMergeMetadataSameEditIsNotAConflict(t *testing.T) {
+	original := map[string]interface{}{"a": "1"}
+	modified := map[string]interface{}{"a": "1-new"}
+	current := map[string]interface{}{"a": "1-new"}
+
+	merged, _, ok := MergeMetadata(original, modified, current)
+	if !ok {
+		t.Fatalf("expected no conflict when both sides made the same edit")
+	}
+	if merged["a"] != "1-new" {
+		t.Errorf("merged[a] = %v, want 1-new", merged["a"])
+	}
+}
+
+func TestMergeMetadataDeletedKeyIsPreserved(t *testing.T) {
+	original := map[string]interface{}{"a": "1", "b": "2"}
+	modified := map[string]interface{}{"a": "1"} // client deleted "b"
+	current := map[string]interface{}{"a": "1", "b": "2", "c": "3"}
+
+	merged, _, ok := MergeMetadata(original, modified, current)
+	if !ok {
+		t.Fatalf("expected no conflict")
+	}
+	if _, present := merged["b"]; present {
+		t.Errorf("expected deleted key %q to stay deleted, got %v", "b", merged["b"])
+	}
+	if merged["c"] != "3" {
+		t.Errorf("expected untouched concurrent key %q to be preserved, got %v", "c", merged["c"])
+	}
+}
+
+func TestMergeMetadataUntouchedKeyKeepsServerValue(t *testing.T) {
+	original := map[string]interface{}{}
+	modified := map[string]interface{}{}
+	current := map[string]interface{}{"a": "server-value"}
+
+	merged, _, ok := MergeMetadata(original, modified, current)
+	if !ok {
+		t.Fatalf("expected no conflict")
+	}
+	if merged["a"] != "server-value" {
+		t.Errorf("merged[a] = %v, want server-value", merged["a"])
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import "github.com/chroma/chroma-coordinator/internal/types"
+
+// Collection is the coordinator's read model for a collection, returned by
+// GetCollections/ListCollections and used as the "current" side when
+// UpdateCollection needs to compare against what is actually stored.
+type Collection struct {
+	ID        types.UniqueID
+	Name      string
+	Topic     string
+	Dimension int32
+	Metadata  map[string]interface{}
+
+	// ResourceVersion is the collection's current optimistic-concurrency
+	// version. Clients round-trip it through UpdateCollection.ResourceVersion
+	// to detect whether their view of the collection is stale.
+	ResourceVersion int64
+
+	// CreatedAtUnixNano orders collections for ListCollections pagination;
+	// see Cursor.
+	CreatedAtUnixNano int64
+
+	// DeletionTimestampUnixNano is when DeleteCollection soft-deleted this
+	// collection, or zero if it is live. GracePeriodSeconds is how long
+	// after DeletionTimestampUnixNano RestoreCollection can still undo the
+	// delete, before the reaper (or a forced PurgeCollection) finalizes it.
+	DeletionTimestampUnixNano int64
+	GracePeriodSeconds        int64
+}
+
+// IsDeleted reports whether c has been soft-deleted and not yet restored
+// or finalized.
+func (c *Collection) IsDeleted() bool {
+	return c.DeletionTimestampUnixNano != 0
+}